@@ -0,0 +1,39 @@
+package stl4go
+
+// Ordered is the set of types that support the <, <=, >, >= operators.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// CompareFn compares two values of the same type, returning a negative number if
+// a < b, a positive number if a > b, and 0 if a == b.
+type CompareFn[T any] func(a, b T) int
+
+// OrderedCompare is a CompareFn for any Ordered type, based on the built-in
+// comparison operators.
+func OrderedCompare[T Ordered](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Min returns the smaller of a and b.
+func Min[T Ordered](a, b T) T {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Pair holds a pair of values of possibly different types.
+type Pair[F, S any] struct {
+	First  F
+	Second S
+}
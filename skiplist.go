@@ -1,12 +1,17 @@
 package stl4go
 
 import (
+	"math"
 	"math/rand"
 	"time"
 )
 
 const (
 	skipListMaxLevel = 40
+	// skipListDefaultP is the probability of promoting a node to the next level used
+	// by NewSkipList. It reproduces the original implementation's behavior, which
+	// promoted levels with the same odds as p=0.5.
+	skipListDefaultP = 0.5
 )
 
 // SkipList is a probabilistic data structure that seem likely to supplant balanced trees as the
@@ -14,37 +19,125 @@ const (
 // asymptotic expected time bounds as balanced trees and are simpler, faster and use less space.
 //
 // See https://en.wikipedia.org/wiki/Skip_list for more details.
-type SkipList[K Ordered, V any] struct {
-	keyCmp CompareFn[K]
-	level  int // Current level, may increase dynamically during insertion
-	len    int // Total elements numner in the skiplist.
-	head   skipListNode[K, V]
+type SkipList[K any, V any] struct {
+	keyCmp   CompareFn[K]
+	level    int     // Current level, may increase dynamically during insertion
+	len      int     // Total elements numner in the skiplist.
+	p        float64 // Probability of promoting a node from one level to the next.
+	maxLevel int     // Hard cap on tower height, see SkipListOptions.MaxLevel.
+	head     skipListNode[K, V]
 	// This cache is used to save the previous nodes when modifying the skip list to avoid
 	// allocating memory each time it is called.
 	prevsCache []*skipListNode[K, V] // Cache to avoid memory allocation.
+	// ranksCache mirrors prevsCache: ranksCache[i] is the rank of prevsCache[i] once
+	// findInsertPoint returns, used to derive span values for Insert/Remove.
+	ranksCache []int
 	rander     *rand.Rand
 }
 
-type skipListNode[K Ordered, V any] struct {
+type skipListNode[K any, V any] struct {
 	key   K
 	value V
 	level int
 	next  []*skipListNode[K, V]
+	// span[i] counts how many level-0 nodes (including the node at next[i] itself)
+	// are skipped by following next[i], enabling O(log n) rank/select via At/Rank.
+	span []int
+	// prev links the level-0 chain backwards, letting a SkipListIterator walk in
+	// descending order. It's nil for the first node (or an unlinked node) and is
+	// only meaningful at level 0; higher levels don't need it.
+	prev *skipListNode[K, V]
 }
 
 // NewSkipList create a new Skiplist.
 func NewSkipList[K Ordered, V any]() *SkipList[K, V] {
-	l := &SkipList[K, V]{
-		level:  1,
-		keyCmp: OrderedCompare[K],
+	return NewSkipListWithOptions[K, V](SkipListOptions[K]{
+		P:         skipListDefaultP,
+		MaxLevel:  skipListMaxLevel,
+		CompareFn: OrderedCompare[K],
+	})
+}
+
+// SkipListOptions configures NewSkipListWithOptions.
+type SkipListOptions[K any] struct {
+	// P is the probability of promoting a node from one level to the next, as in
+	// Pugh's paper. Lower values (e.g. 0.25, as used by Redis) trade worse constant
+	// factors for lower memory use. Defaults to 0.25 if zero.
+	P float64
+	// MaxLevel bounds how tall a node's tower can grow. If zero, it's derived from
+	// ExpectedSize as ceil(log_{1/P}(ExpectedSize)); if ExpectedSize is also zero,
+	// it falls back to skipListMaxLevel.
+	MaxLevel int
+	// ExpectedSize is used to derive MaxLevel when MaxLevel is zero. It's ignored
+	// otherwise.
+	ExpectedSize int
+	// Rand, if set, is used for level generation instead of a time-seeded source,
+	// making level heights (and so iteration timing, but not key order) deterministic.
+	Rand *rand.Rand
+	// CompareFn orders keys. It's required: SkipListOptions places no constraint on
+	// K, so there's no default ordering to fall back on.
+	CompareFn CompareFn[K]
+}
+
+const skipListDefaultOptionsP = 0.25
+
+// NewSkipListWithOptions creates a new Skiplist with a caller-chosen promotion
+// probability, level cap and key comparator, which makes it usable with keys that
+// don't satisfy Ordered (e.g. reverse order, composite keys) as long as a
+// CompareFn is supplied.
+func NewSkipListWithOptions[K any, V any](opts SkipListOptions[K]) *SkipList[K, V] {
+	if opts.CompareFn == nil {
+		panic("stl4go: SkipListOptions.CompareFn is required")
+	}
+
+	p := opts.P
+	if p <= 0 || p >= 1 {
+		p = skipListDefaultOptionsP
+	}
+
+	maxLevel := opts.MaxLevel
+	if maxLevel <= 0 {
+		if opts.ExpectedSize > 0 {
+			maxLevel = skipListLevelForSize(opts.ExpectedSize, p)
+		} else {
+			maxLevel = skipListMaxLevel
+		}
+	}
+
+	rander := opts.Rand
+	if rander == nil {
 		// #nosec G404 -- This is not a security condition
-		rander:     rand.New(rand.NewSource(time.Now().Unix())),
-		prevsCache: make([]*skipListNode[K, V], skipListMaxLevel),
+		rander = rand.New(rand.NewSource(time.Now().Unix()))
 	}
-	l.head.next = make([]*skipListNode[K, V], skipListMaxLevel)
+
+	l := &SkipList[K, V]{
+		level:      1,
+		keyCmp:     opts.CompareFn,
+		p:          p,
+		maxLevel:   maxLevel,
+		rander:     rander,
+		prevsCache: make([]*skipListNode[K, V], maxLevel),
+		ranksCache: make([]int, maxLevel),
+	}
+	l.head.next = make([]*skipListNode[K, V], maxLevel)
+	l.head.span = make([]int, maxLevel)
 	return l
 }
 
+// skipListLevelForSize derives a MaxLevel from an expected element count n and
+// promotion probability p, so a list never grows an unnecessarily tall tower for
+// the size it's expected to hold.
+func skipListLevelForSize(n int, p float64) int {
+	if n <= 1 {
+		return 1
+	}
+	level := int(math.Ceil(math.Log(float64(n)) / math.Log(1/p)))
+	if level < 1 {
+		level = 1
+	}
+	return level
+}
+
 // NewSkipListFromMap create a new Skiplist from a map.
 func NewSkipListFromMap[K Ordered, V any](m map[K]V) *SkipList[K, V] {
 	sl := NewSkipList[K, V]()
@@ -72,7 +165,7 @@ func (sl *SkipList[K, V]) Clear() {
 
 // Insert inserts a key-value pair into the skiplist
 func (sl *SkipList[K, V]) Insert(key K, value V) {
-	eq, prevs := sl.findInsertPoint(key)
+	eq, prevs, ranks := sl.findInsertPoint(key)
 
 	if eq != nil {
 		// Already exist, update the value
@@ -82,22 +175,45 @@ func (sl *SkipList[K, V]) Insert(key K, value V) {
 
 	level := sl.randomLevel()
 
+	if level > sl.level {
+		prevs = sl.prevsCache[0:level]
+		ranks = sl.ranksCache[0:level]
+		for i := sl.level; i < level; i++ {
+			ranks[i] = 0
+			prevs[i] = &sl.head
+			// head.span[i] is set below once e's own span at this level is known,
+			// not seeded here.
+		}
+	}
+
 	e := &skipListNode[K, V]{
 		key:   key,
 		value: value,
 		level: level,
 		next:  make([]*skipListNode[K, V], level),
+		span:  make([]int, level),
 	}
 
-	for i := 0; i < Min(level, sl.level); i++ {
+	for i := 0; i < level; i++ {
 		e.next[i] = prevs[i].next[i]
 		prevs[i].next[i] = e
+
+		e.span[i] = prevs[i].span[i] - (ranks[0] - ranks[i])
+		prevs[i].span[i] = (ranks[0] - ranks[i]) + 1
+	}
+
+	for i := level; i < sl.level; i++ {
+		prevs[i].span[i]++
+	}
+
+	if prevs[0] != &sl.head {
+		e.prev = prevs[0]
+	}
+	if e.next[0] != nil {
+		e.next[0].prev = e
 	}
 
 	if level > sl.level {
-		for i := sl.level; i < level; i++ {
-			sl.head.next[i] = e
-		}
 		sl.level = level
 	}
 
@@ -127,6 +243,13 @@ func (sl *SkipList[K, V]) Remove(key K) bool {
 	}
 	for i, v := range node.next {
 		prevs[i].next[i] = v
+		prevs[i].span[i] += node.span[i] - 1
+	}
+	for i := node.level; i < sl.level; i++ {
+		prevs[i].span[i]--
+	}
+	if node.next[0] != nil {
+		node.next[0].prev = node.prev
 	}
 	for sl.level > 2 && sl.head.next[sl.level-1] == nil {
 		sl.level--
@@ -135,6 +258,44 @@ func (sl *SkipList[K, V]) Remove(key K) bool {
 	return true
 }
 
+// At returns the rank-th smallest key-value pair (0-indexed) in O(log n). It
+// returns the zero K and a nil *V if rank is out of range.
+func (sl *SkipList[K, V]) At(rank int) (K, *V) {
+	node := sl.nodeAt(rank)
+	if node == nil {
+		var zero K
+		return zero, nil
+	}
+	return node.key, &node.value
+}
+
+// Rank returns the number of elements strictly less than key, in O(log n). If key
+// is present, this also equals the rank passed to At to retrieve it.
+func (sl *SkipList[K, V]) Rank(key K) int {
+	prev := &sl.head
+	rank := 0
+	for i := sl.level - 1; i >= 0; i-- {
+		for next := prev.next[i]; next != nil; next = next.next[i] {
+			if sl.keyCmp(next.key, key) >= 0 {
+				break
+			}
+			rank += prev.span[i]
+			prev = next
+		}
+	}
+	return rank
+}
+
+// RemoveAt removes the rank-th smallest key-value pair (0-indexed) in O(log n) and
+// returns true, or returns false if rank is out of range.
+func (sl *SkipList[K, V]) RemoveAt(rank int) bool {
+	node := sl.nodeAt(rank)
+	if node == nil {
+		return false
+	}
+	return sl.Remove(node.key)
+}
+
 func (sl *SkipList[K, V]) ForEach(op func(K, *V)) {
 	for e := sl.head.next[0]; e != nil; e = e.next[0] {
 		op(e.key, &e.value)
@@ -149,16 +310,13 @@ func (sl *SkipList[K, V]) ForEachIf(op func(K, *V) bool) {
 	}
 }
 
+// randomLevel draws a tower height via a geometric distribution: starting at 1, it
+// keeps promoting to the next level with probability sl.p, capped at sl.maxLevel.
 func (sl *SkipList[K, V]) randomLevel() int {
-	total := uint64(1)<<uint64(skipListMaxLevel) - 1 // 2^n-1
-	k := sl.rander.Uint64() % total
-	levelN := uint64(1) << (uint64(skipListMaxLevel) - 1)
-
 	level := 1
-	for total -= levelN; total > k; level++ {
-		levelN >>= 1
-		total -= levelN
-		// Since levels are randomly generated, most should be less than log2(s.len).
+	for level < sl.maxLevel && sl.rander.Float64() < sl.p {
+		level++
+		// Since levels are randomly generated, most should be less than log2(sl.len).
 		// Then make a limit according to sl.len to avoid unexpectedly large value.
 		if level > 2 && 1<<(level-2) > sl.len {
 			break
@@ -185,27 +343,33 @@ func (sl *SkipList[K, V]) findNode(key K) *skipListNode[K, V] {
 	return nil
 }
 
-// findInsertPoint returns (*node, nil) to the existed node if the key exists,
-// or (nil, []*node) to the previous nodes if the key doesn't exist
-func (sl *SkipList[K, V]) findInsertPoint(key K) (*skipListNode[K, V], []*skipListNode[K, V]) {
+// findInsertPoint returns (*node, nil, nil) to the existed node if the key exists,
+// or (nil, []*node, []int) to the previous nodes and their ranks if the key doesn't
+// exist. ranks[i] is prevs[i]'s position along level 0, counting the head as 0
+// (one more than the rank At uses for the same node), used to derive span values.
+func (sl *SkipList[K, V]) findInsertPoint(key K) (*skipListNode[K, V], []*skipListNode[K, V], []int) {
 	prevs := sl.prevsCache[0:sl.level]
+	ranks := sl.ranksCache[0:sl.level]
 	prev := &sl.head
+	rank := 0
 	for i := sl.level - 1; i >= 0; i-- {
 		if sl.head.next[i] != nil {
 			for next := prev.next[i]; next != nil; next = next.next[i] {
 				r := sl.keyCmp(next.key, key)
 				if r == 0 {
-					return next, nil
+					return next, nil, nil
 				}
 				if r > 0 {
 					break
 				}
+				rank += prev.span[i]
 				prev = next
 			}
 		}
 		prevs[i] = prev
+		ranks[i] = rank
 	}
-	return nil, prevs
+	return nil, prevs, ranks
 }
 
 func (sl *SkipList[K, V]) findRemovePoint(key K) (*skipListNode[K, V], []*skipListNode[K, V]) {
@@ -235,4 +399,160 @@ func (sl *SkipList[K, V]) findPrevNodes(key K) []*skipListNode[K, V] {
 		prevs[i] = prev
 	}
 	return prevs
+}
+
+// nodeAt returns the node at the given 0-indexed rank, or nil if rank is out of
+// range. It walks from the head, descending a level whenever following span[i]
+// would overshoot the target rank, giving O(log n) order-statistic lookup.
+func (sl *SkipList[K, V]) nodeAt(rank int) *skipListNode[K, V] {
+	if rank < 0 || rank >= sl.len {
+		return nil
+	}
+	target := rank + 1 // ranks are 0-indexed; the head itself occupies rank 0.
+	node := &sl.head
+	traversed := 0
+	for i := sl.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && traversed+node.span[i] <= target {
+			traversed += node.span[i]
+			node = node.next[i]
+		}
+		if traversed == target {
+			return node
+		}
+	}
+	return nil
+}
+
+// findLast returns the node with the largest key, or nil if the skiplist is empty.
+func (sl *SkipList[K, V]) findLast() *skipListNode[K, V] {
+	node := &sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for node.next[i] != nil {
+			node = node.next[i]
+		}
+	}
+	if node == &sl.head {
+		return nil
+	}
+	return node
+}
+
+// findLastLE returns the node with the largest key that is <= key, or nil if no
+// such node exists.
+func (sl *SkipList[K, V]) findLastLE(key K) *skipListNode[K, V] {
+	prev := &sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for next := prev.next[i]; next != nil; next = next.next[i] {
+			if sl.keyCmp(next.key, key) > 0 {
+				break
+			}
+			prev = next
+		}
+	}
+	if prev == &sl.head {
+		return nil
+	}
+	return prev
+}
+
+// Range calls op with every key-value pair in [lo, hi] in ascending key order,
+// stopping early if op returns false. It locates lo in O(log n) by reusing the
+// findPrevNodes traversal instead of scanning from the beginning.
+func (sl *SkipList[K, V]) Range(lo, hi K, op func(K, *V) bool) {
+	prevs := sl.findPrevNodes(lo)
+	for node := prevs[0].next[0]; node != nil && sl.keyCmp(node.key, hi) <= 0; node = node.next[0] {
+		if !op(node.key, &node.value) {
+			return
+		}
+	}
+}
+
+// Iterator returns an unpositioned SkipListIterator: Valid() is false and Next/Prev
+// are no-ops until SeekToFirst, SeekToLast, Seek or SeekForPrev is called.
+func (sl *SkipList[K, V]) Iterator() *SkipListIterator[K, V] {
+	return &SkipListIterator[K, V]{sl: sl}
+}
+
+// LowerBound returns an iterator positioned at the first key >= key, or an invalid
+// iterator if no such key exists.
+func (sl *SkipList[K, V]) LowerBound(key K) *SkipListIterator[K, V] {
+	it := sl.Iterator()
+	it.Seek(key)
+	return it
+}
+
+// UpperBound returns an iterator positioned at the first key > key, or an invalid
+// iterator if no such key exists.
+func (sl *SkipList[K, V]) UpperBound(key K) *SkipListIterator[K, V] {
+	it := sl.Iterator()
+	prevs := sl.findPrevNodes(key)
+	node := prevs[0].next[0]
+	if node != nil && sl.keyCmp(node.key, key) == 0 {
+		node = node.next[0]
+	}
+	it.cur = node
+	return it
+}
+
+// SkipListIterator traverses a SkipList in ascending or descending key order. It's
+// positioned on one node at a time, or is invalid (Valid() == false) when it has
+// run off either end. An iterator is not safe for concurrent use, and is
+// invalidated by concurrent modification of the underlying SkipList.
+type SkipListIterator[K any, V any] struct {
+	sl  *SkipList[K, V]
+	cur *skipListNode[K, V]
+}
+
+// SeekToFirst positions the iterator at the smallest key.
+func (it *SkipListIterator[K, V]) SeekToFirst() {
+	it.cur = it.sl.head.next[0]
+}
+
+// SeekToLast positions the iterator at the largest key.
+func (it *SkipListIterator[K, V]) SeekToLast() {
+	it.cur = it.sl.findLast()
+}
+
+// Seek positions the iterator at the first key >= key.
+func (it *SkipListIterator[K, V]) Seek(key K) {
+	prevs := it.sl.findPrevNodes(key)
+	it.cur = prevs[0].next[0]
+}
+
+// SeekForPrev positions the iterator at the last key <= key.
+func (it *SkipListIterator[K, V]) SeekForPrev(key K) {
+	it.cur = it.sl.findLastLE(key)
+}
+
+// Next advances the iterator to the next-larger key. It's a no-op if the iterator
+// is already invalid.
+func (it *SkipListIterator[K, V]) Next() {
+	if it.cur != nil {
+		it.cur = it.cur.next[0]
+	}
+}
+
+// Prev moves the iterator to the next-smaller key. It's a no-op if the iterator is
+// already invalid.
+func (it *SkipListIterator[K, V]) Prev() {
+	if it.cur != nil {
+		it.cur = it.cur.prev
+	}
+}
+
+// Valid reports whether the iterator is currently positioned on an element.
+func (it *SkipListIterator[K, V]) Valid() bool {
+	return it.cur != nil
+}
+
+// Key returns the key the iterator is currently positioned at. It must only be
+// called when Valid() is true.
+func (it *SkipListIterator[K, V]) Key() K {
+	return it.cur.key
+}
+
+// Value returns the value the iterator is currently positioned at. It must only be
+// called when Valid() is true.
+func (it *SkipListIterator[K, V]) Value() *V {
+	return &it.cur.value
 }
\ No newline at end of file
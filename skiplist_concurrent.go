@@ -0,0 +1,243 @@
+package stl4go
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+const defaultConcurrentSkipListShards = 32
+
+// ShardFn computes a shard key for a given key. Implementations don't need to be
+// uniformly distributed across the full uint64 range, only across the number of
+// shards a ConcurrentSkipList was created with.
+type ShardFn[K Ordered] func(key K) uint64
+
+// defaultShardFn is numeric-friendly: predeclared integer and float types are
+// hashed by bit-mixing their value directly (no allocation, no reflection), and
+// strings are hashed byte-by-byte, both avoiding the allocating, reflection-driven
+// fmt.Sprintf formatting that would otherwise sit on every Insert/Find/Remove. Only
+// a defined type over one of those kinds (e.g. `type UserID int64`) falls through
+// to the fmt-based path, since a type switch can't see through to its underlying
+// kind.
+func defaultShardFn[K Ordered](key K) uint64 {
+	switch v := any(key).(type) {
+	case int:
+		return splitmix64(uint64(v))
+	case int8:
+		return splitmix64(uint64(v))
+	case int16:
+		return splitmix64(uint64(v))
+	case int32:
+		return splitmix64(uint64(v))
+	case int64:
+		return splitmix64(uint64(v))
+	case uint:
+		return splitmix64(uint64(v))
+	case uint8:
+		return splitmix64(uint64(v))
+	case uint16:
+		return splitmix64(uint64(v))
+	case uint32:
+		return splitmix64(uint64(v))
+	case uint64:
+		return splitmix64(v)
+	case uintptr:
+		return splitmix64(uint64(v))
+	case float32:
+		return splitmix64(uint64(math.Float32bits(v)))
+	case float64:
+		return splitmix64(math.Float64bits(v))
+	case string:
+		return fnv1aString(v)
+	default:
+		return fnv1aString(fmt.Sprintf("%v", key))
+	}
+}
+
+// splitmix64 is splitmix64's finalizer: a cheap, allocation-free avalanche mix
+// that turns a numeric key into a well-distributed uint64 shard hash.
+func splitmix64(x uint64) uint64 {
+	x += 0x9E3779B97F4A7C15
+	x = (x ^ (x >> 30)) * 0xBF58476D1CE4E5B9
+	x = (x ^ (x >> 27)) * 0x94D049BB133111EB
+	return x ^ (x >> 31)
+}
+
+// fnv1aString hashes s with FNV-1a, iterating over its bytes directly instead of
+// converting to []byte so no copy is made.
+func fnv1aString(s string) uint64 {
+	const offset64 = 14695981039346656037
+	const prime64 = 1099511628211
+
+	h := uint64(offset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= prime64
+	}
+	return h
+}
+
+// skipListShard is one sub-skiplist plus the lock that guards it. len is kept as an
+// atomically-updated counter so ConcurrentSkipList.Len doesn't need to lock every
+// shard to answer a size query.
+type skipListShard[K Ordered, V any] struct {
+	mu  sync.RWMutex
+	sl  *SkipList[K, V]
+	len int64
+}
+
+// ConcurrentSkipList is a thread-safe SkipList that shards its keyspace across N
+// independent SkipList instances, each protected by its own sync.RWMutex, so that
+// operations on unrelated keys don't contend on a single lock.
+//
+// Each shard owns a private SkipList, so per-shard state such as prevsCache is never
+// shared between shards, and within a shard it's only used by Insert/Remove while
+// the shard's write lock is held; the only state shared across shards is the
+// shards slice itself, which is fixed at construction time.
+type ConcurrentSkipList[K Ordered, V any] struct {
+	shards  []*skipListShard[K, V]
+	shardFn ShardFn[K]
+}
+
+// NewConcurrentSkipList creates a ConcurrentSkipList with the given number of shards,
+// using a default ShardFn (splitmix64 for numeric keys, FNV-1a for strings). If
+// shards <= 0, defaultConcurrentSkipListShards
+// is used.
+func NewConcurrentSkipList[K Ordered, V any](shards int) *ConcurrentSkipList[K, V] {
+	return NewConcurrentSkipListWithShardFn[K, V](shards, defaultShardFn[K])
+}
+
+// NewConcurrentSkipListWithShardFn creates a ConcurrentSkipList using a caller-supplied
+// ShardFn, which is useful when keys are numeric and a cheaper hash than the default
+// is available.
+func NewConcurrentSkipListWithShardFn[K Ordered, V any](shards int, shardFn ShardFn[K]) *ConcurrentSkipList[K, V] {
+	if shards <= 0 {
+		shards = defaultConcurrentSkipListShards
+	}
+
+	csl := &ConcurrentSkipList[K, V]{
+		shards:  make([]*skipListShard[K, V], shards),
+		shardFn: shardFn,
+	}
+	for i := range csl.shards {
+		csl.shards[i] = &skipListShard[K, V]{sl: NewSkipList[K, V]()}
+	}
+	return csl
+}
+
+func (csl *ConcurrentSkipList[K, V]) shardFor(key K) *skipListShard[K, V] {
+	idx := csl.shardFn(key) % uint64(len(csl.shards))
+	return csl.shards[idx]
+}
+
+// Insert inserts a key-value pair into the skiplist.
+func (csl *ConcurrentSkipList[K, V]) Insert(key K, value V) {
+	sh := csl.shardFor(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	before := sh.sl.Len()
+	sh.sl.Insert(key, value)
+	if sh.sl.Len() > before {
+		atomic.AddInt64(&sh.len, 1)
+	}
+}
+
+// Find returns the value associated with the passed key if the key is in the
+// skiplist, otherwise returns nil.
+func (csl *ConcurrentSkipList[K, V]) Find(key K) *V {
+	sh := csl.shardFor(key)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	return sh.sl.Find(key)
+}
+
+func (csl *ConcurrentSkipList[K, V]) Has(key K) bool {
+	sh := csl.shardFor(key)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	return sh.sl.Has(key)
+}
+
+// Remove removes the key-value pair associated with the passed key and returns true
+// if the key was in the skiplist, otherwise returns false.
+func (csl *ConcurrentSkipList[K, V]) Remove(key K) bool {
+	sh := csl.shardFor(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	ok := sh.sl.Remove(key)
+	if ok {
+		atomic.AddInt64(&sh.len, -1)
+	}
+	return ok
+}
+
+// Len returns the total number of elements across all shards. It sums each shard's
+// atomically-tracked counter rather than locking every shard.
+func (csl *ConcurrentSkipList[K, V]) Len() int {
+	var total int64
+	for _, sh := range csl.shards {
+		total += atomic.LoadInt64(&sh.len)
+	}
+	return int(total)
+}
+
+func (csl *ConcurrentSkipList[K, V]) IsEmpty() bool {
+	return csl.Len() == 0
+}
+
+// mergeCursor walks the level-0 chain of one shard while the shard's RLock is held.
+type mergeCursor[K Ordered, V any] struct {
+	node *skipListNode[K, V]
+	cmp  CompareFn[K]
+}
+
+// cursorHeap is a container/heap.Interface over mergeCursors, ordered by the current
+// node's key so the top of the heap is always the globally-smallest remaining key.
+type cursorHeap[K Ordered, V any] []*mergeCursor[K, V]
+
+func (h cursorHeap[K, V]) Len() int            { return len(h) }
+func (h cursorHeap[K, V]) Less(i, j int) bool  { return h[i].cmp(h[i].node.key, h[j].node.key) < 0 }
+func (h cursorHeap[K, V]) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *cursorHeap[K, V]) Push(x any)         { *h = append(*h, x.(*mergeCursor[K, V])) }
+func (h *cursorHeap[K, V]) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// ForEach visits every key-value pair in ascending key order. It snapshots all
+// shards (holding every shard's RLock for the duration of the call) and k-way merges
+// their per-shard, already-sorted level-0 chains so the global iteration order is
+// preserved even though keys are distributed across shards by hash, not by range.
+func (csl *ConcurrentSkipList[K, V]) ForEach(op func(K, *V)) {
+	for _, sh := range csl.shards {
+		sh.mu.RLock()
+		defer sh.mu.RUnlock()
+	}
+
+	h := make(cursorHeap[K, V], 0, len(csl.shards))
+	for _, sh := range csl.shards {
+		if n := sh.sl.head.next[0]; n != nil {
+			h = append(h, &mergeCursor[K, V]{node: n, cmp: sh.sl.keyCmp})
+		}
+	}
+	heap.Init(&h)
+
+	for h.Len() > 0 {
+		c := h[0]
+		op(c.node.key, &c.node.value)
+		if next := c.node.next[0]; next != nil {
+			c.node = next
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package stl4go
+
+import (
+	"bufio"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+const (
+	skipListMagic   uint32 = 0x53544c31 // "STL1"
+	skipListVersion uint32 = 1
+)
+
+// ErrBadSkipListFormat is returned by ReadSkipList when the stream doesn't start
+// with a valid snapshot header.
+var ErrBadSkipListFormat = errors.New("stl4go: not a valid skiplist snapshot")
+
+// SkipListEncoding selects the on-disk format used by WriteTo/ReadSkipList.
+type SkipListEncoding int
+
+const (
+	// SkipListEncodingGob encodes keys and values with encoding/gob. It works for
+	// any K/V but is slower and less compact than SkipListEncodingBinary.
+	SkipListEncodingGob SkipListEncoding = iota
+	// SkipListEncodingBinary encodes keys and values with their
+	// encoding.BinaryMarshaler/BinaryUnmarshaler implementations. K must implement
+	// encoding.BinaryMarshaler, *K encoding.BinaryUnmarshaler, and *V both, or
+	// WriteToWithOptions/ReadSkipList return an error.
+	SkipListEncodingBinary
+)
+
+// SkipListIOOptions configures WriteTo/ReadSkipList.
+type SkipListIOOptions struct {
+	Encoding SkipListEncoding
+}
+
+// WriteTo serializes sl to w in ascending key order using SkipListEncodingGob, so
+// it can be rebuilt in O(n) by ReadSkipList without re-running Insert for every
+// element. Use WriteToWithOptions to pick a more compact encoding.
+func (sl *SkipList[K, V]) WriteTo(w io.Writer) (int64, error) {
+	return sl.WriteToWithOptions(w, SkipListIOOptions{Encoding: SkipListEncodingGob})
+}
+
+// WriteToWithOptions is WriteTo with an explicit SkipListIOOptions.
+func (sl *SkipList[K, V]) WriteToWithOptions(w io.Writer, opts SkipListIOOptions) (int64, error) {
+	cw := &countingWriter{w: w}
+
+	header := [3]uint32{skipListMagic, skipListVersion, uint32(opts.Encoding)}
+	if err := binary.Write(cw, binary.BigEndian, header); err != nil {
+		return cw.n, err
+	}
+	if err := binary.Write(cw, binary.BigEndian, uint64(sl.len)); err != nil {
+		return cw.n, err
+	}
+
+	var err error
+	switch opts.Encoding {
+	case SkipListEncodingGob:
+		enc := gob.NewEncoder(cw)
+		sl.ForEachIf(func(k K, v *V) bool {
+			if err = enc.Encode(&k); err != nil {
+				return false
+			}
+			err = enc.Encode(v)
+			return err == nil
+		})
+	case SkipListEncodingBinary:
+		sl.ForEachIf(func(k K, v *V) bool {
+			if err = writeBinaryField(cw, any(k)); err != nil {
+				return false
+			}
+			err = writeBinaryField(cw, any(v))
+			return err == nil
+		})
+	default:
+		err = fmt.Errorf("stl4go: unknown SkipListEncoding %d", opts.Encoding)
+	}
+	return cw.n, err
+}
+
+// ReadSkipList reconstructs a SkipList previously written by WriteTo. It rebuilds
+// the level structure directly from the sorted snapshot in O(n), bypassing Insert.
+func ReadSkipList[K Ordered, V any](r io.Reader) (*SkipList[K, V], error) {
+	br := bufio.NewReader(r)
+
+	var header [3]uint32
+	if err := binary.Read(br, binary.BigEndian, &header); err != nil {
+		return nil, err
+	}
+	if header[0] != skipListMagic {
+		return nil, ErrBadSkipListFormat
+	}
+	if header[1] != skipListVersion {
+		return nil, fmt.Errorf("stl4go: unsupported skiplist snapshot version %d", header[1])
+	}
+	enc := SkipListEncoding(header[2])
+
+	var n uint64
+	if err := binary.Read(br, binary.BigEndian, &n); err != nil {
+		return nil, err
+	}
+
+	keys := make([]K, n)
+	values := make([]V, n)
+	switch enc {
+	case SkipListEncodingGob:
+		dec := gob.NewDecoder(br)
+		for i := range keys {
+			if err := dec.Decode(&keys[i]); err != nil {
+				return nil, err
+			}
+			if err := dec.Decode(&values[i]); err != nil {
+				return nil, err
+			}
+		}
+	case SkipListEncodingBinary:
+		for i := range keys {
+			if err := readBinaryField(br, any(&keys[i])); err != nil {
+				return nil, err
+			}
+			if err := readBinaryField(br, any(&values[i])); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("stl4go: unknown SkipListEncoding %d", enc)
+	}
+
+	sl := NewSkipList[K, V]()
+	buildSkipListFromSorted(sl, keys, values)
+	return sl, nil
+}
+
+func writeBinaryField(w io.Writer, v any) error {
+	m, ok := v.(encoding.BinaryMarshaler)
+	if !ok {
+		return fmt.Errorf("stl4go: %T does not implement encoding.BinaryMarshaler", v)
+	}
+	data, err := m.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(len(data))); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func readBinaryField(r io.Reader, dst any) error {
+	u, ok := dst.(encoding.BinaryUnmarshaler)
+	if !ok {
+		return fmt.Errorf("stl4go: %T does not implement encoding.BinaryUnmarshaler", dst)
+	}
+	var size uint32
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return err
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return err
+	}
+	return u.UnmarshalBinary(data)
+}
+
+// buildSkipListFromSorted resets sl and rebuilds it in O(n) from keys/values
+// already in ascending order, maintaining a per-level tail pointer instead of
+// re-running findInsertPoint for every element. Levels are drawn fresh via
+// randomLevel rather than persisted, since the resulting list has the same
+// expected query shape. sl's keyCmp/p/maxLevel/rander are left untouched, so it
+// can rebuild a SkipList constructed with custom SkipListOptions.
+func buildSkipListFromSorted[K any, V any](sl *SkipList[K, V], keys []K, values []V) {
+	sl.level = 1
+	sl.len = 0
+	for i := range sl.head.next {
+		sl.head.next[i] = nil
+		sl.head.span[i] = 0
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	tails := make([]*skipListNode[K, V], sl.maxLevel)
+	gaps := make([]int, sl.maxLevel)
+	var prevNode *skipListNode[K, V]
+
+	for i, key := range keys {
+		// randomLevel bounds tower height by sl.len, so keep it at the number of
+		// nodes linked so far, as Insert would see it.
+		sl.len = i
+		level := sl.randomLevel()
+		if level > sl.level {
+			sl.level = level
+		}
+
+		e := &skipListNode[K, V]{
+			key:   key,
+			value: values[i],
+			level: level,
+			next:  make([]*skipListNode[K, V], level),
+			span:  make([]int, level),
+			prev:  prevNode,
+		}
+
+		for lvl := 0; lvl < level; lvl++ {
+			if tails[lvl] == nil {
+				sl.head.next[lvl] = e
+				sl.head.span[lvl] = gaps[lvl] + 1
+			} else {
+				tails[lvl].next[lvl] = e
+				tails[lvl].span[lvl] = gaps[lvl] + 1
+			}
+			tails[lvl] = e
+			gaps[lvl] = 0
+		}
+		// Accumulate against maxLevel, not the live sl.level: sl.level only grows
+		// as taller nodes are seen, but a level that isn't live yet still needs
+		// every shorter node counted into its gap for whenever it does go live.
+		for lvl := level; lvl < sl.maxLevel; lvl++ {
+			gaps[lvl]++
+		}
+
+		prevNode = e
+	}
+
+	sl.len = len(keys)
+}
+
+// ErrUnsortedInput is returned by NewSkipListFromSortedChecked when keys aren't in
+// strictly ascending order.
+var ErrUnsortedInput = errors.New("stl4go: input keys are not sorted")
+
+// NewSkipListFromSorted builds a SkipList in O(n) from keys and values already in
+// ascending key order, skipping the O(log n) findInsertPoint traversal Insert
+// would otherwise do for every element. The caller must guarantee the input is
+// sorted; use NewSkipListFromSortedChecked to have that verified instead.
+func NewSkipListFromSorted[K Ordered, V any](keys []K, values []V) *SkipList[K, V] {
+	if len(keys) != len(values) {
+		panic("stl4go: keys and values must have the same length")
+	}
+	sl := NewSkipList[K, V]()
+	buildSkipListFromSorted(sl, keys, values)
+	return sl
+}
+
+// NewSkipListFromSortedChecked is NewSkipListFromSorted but verifies keys are in
+// strictly ascending order first, returning ErrUnsortedInput instead of building a
+// corrupt skiplist if they aren't.
+func NewSkipListFromSortedChecked[K Ordered, V any](keys []K, values []V) (*SkipList[K, V], error) {
+	cmp := OrderedCompare[K]
+	for i := 1; i < len(keys); i++ {
+		if cmp(keys[i-1], keys[i]) >= 0 {
+			return nil, ErrUnsortedInput
+		}
+	}
+	return NewSkipListFromSorted(keys, values), nil
+}
+
+// BulkInsert inserts pairs into sl. If pairs aren't already in strictly-ascending,
+// duplicate-free key order, they're sorted (a copy is made so the caller's slice
+// isn't reordered) and collapsed down to one entry per key, keeping the last pair
+// for each repeated key so the fast path matches Insert's last-write-wins
+// semantics. If sl is empty, it's then built in O(n) using the same tails-array
+// technique as NewSkipListFromSorted; otherwise pairs are inserted one at a time.
+func (sl *SkipList[K, V]) BulkInsert(pairs []Pair[K, V]) {
+	if len(pairs) == 0 {
+		return
+	}
+
+	sortedUnique := true
+	for i := 1; i < len(pairs); i++ {
+		if sl.keyCmp(pairs[i-1].First, pairs[i].First) >= 0 {
+			sortedUnique = false
+			break
+		}
+	}
+	if !sortedUnique {
+		pairs = append([]Pair[K, V](nil), pairs...)
+		sort.SliceStable(pairs, func(i, j int) bool {
+			return sl.keyCmp(pairs[i].First, pairs[j].First) < 0
+		})
+		pairs = dedupeSortedPairs(sl.keyCmp, pairs)
+	}
+
+	if sl.IsEmpty() {
+		keys := make([]K, len(pairs))
+		values := make([]V, len(pairs))
+		for i, p := range pairs {
+			keys[i] = p.First
+			values[i] = p.Second
+		}
+		buildSkipListFromSorted(sl, keys, values)
+		return
+	}
+
+	for _, p := range pairs {
+		sl.Insert(p.First, p.Second)
+	}
+}
+
+// dedupeSortedPairs compresses pairs (sorted ascending by key via a stable sort,
+// so runs of equal keys keep their original relative order) down to one entry per
+// key, keeping the last pair in each run. It reuses pairs' backing array: the
+// write cursor never outruns the read cursor, so this is safe in place.
+func dedupeSortedPairs[K any, V any](cmp CompareFn[K], pairs []Pair[K, V]) []Pair[K, V] {
+	out := pairs[:0]
+	for i, p := range pairs {
+		if i > 0 && cmp(pairs[i-1].First, p.First) == 0 {
+			out[len(out)-1] = p
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// countingWriter wraps an io.Writer to track the total number of bytes written, so
+// WriteTo/WriteToWithOptions can report it the same way io.WriterTo does elsewhere.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
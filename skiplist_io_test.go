@@ -0,0 +1,167 @@
+package stl4go
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+)
+
+// binKey and binVal are minimal encoding.BinaryMarshaler/BinaryUnmarshaler
+// implementations used to exercise SkipListEncodingBinary.
+type binKey int32
+
+func (k binKey) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(k))
+	return b, nil
+}
+
+func (k *binKey) UnmarshalBinary(data []byte) error {
+	*k = binKey(binary.BigEndian.Uint32(data))
+	return nil
+}
+
+type binVal string
+
+func (v binVal) MarshalBinary() ([]byte, error) {
+	return []byte(v), nil
+}
+
+func (v *binVal) UnmarshalBinary(data []byte) error {
+	*v = binVal(data)
+	return nil
+}
+
+func TestSkipListWriteToReadSkipListGobRoundTrip(t *testing.T) {
+	sl := NewSkipList[int, string]()
+	want := map[int]string{}
+	for i := 0; i < 200; i++ {
+		key := i * 3
+		val := fmt.Sprintf("v%d", i)
+		sl.Insert(key, val)
+		want[key] = val
+	}
+
+	var buf bytes.Buffer
+	n, err := sl.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Fatalf("WriteTo returned %d, but wrote %d bytes", n, buf.Len())
+	}
+
+	got, err := ReadSkipList[int, string](&buf)
+	if err != nil {
+		t.Fatalf("ReadSkipList: %v", err)
+	}
+
+	if got.Len() != sl.Len() {
+		t.Fatalf("Len() = %d, want %d", got.Len(), sl.Len())
+	}
+	for k, v := range want {
+		gv := got.Find(k)
+		if gv == nil || *gv != v {
+			t.Fatalf("Find(%d) = %v, want %q", k, gv, v)
+		}
+	}
+
+	var gotKeys []int
+	got.ForEach(func(k int, _ *string) {
+		gotKeys = append(gotKeys, k)
+	})
+	for i := 1; i < len(gotKeys); i++ {
+		if gotKeys[i-1] >= gotKeys[i] {
+			t.Fatalf("loaded skiplist is not in ascending order: %v", gotKeys)
+		}
+	}
+}
+
+func TestSkipListWriteToReadSkipListBinaryRoundTrip(t *testing.T) {
+	sl := NewSkipList[binKey, binVal]()
+	for i := 0; i < 100; i++ {
+		sl.Insert(binKey(i*2), binVal(fmt.Sprintf("val-%d", i)))
+	}
+
+	var buf bytes.Buffer
+	opts := SkipListIOOptions{Encoding: SkipListEncodingBinary}
+	if _, err := sl.WriteToWithOptions(&buf, opts); err != nil {
+		t.Fatalf("WriteToWithOptions: %v", err)
+	}
+
+	got, err := ReadSkipList[binKey, binVal](&buf)
+	if err != nil {
+		t.Fatalf("ReadSkipList: %v", err)
+	}
+	if got.Len() != sl.Len() {
+		t.Fatalf("Len() = %d, want %d", got.Len(), sl.Len())
+	}
+
+	var mismatch error
+	sl.ForEachIf(func(k binKey, v *binVal) bool {
+		gv := got.Find(k)
+		if gv == nil || *gv != *v {
+			mismatch = fmt.Errorf("Find(%v) = %v, want %q", k, gv, *v)
+			return false
+		}
+		return true
+	})
+	if mismatch != nil {
+		t.Fatal(mismatch)
+	}
+}
+
+func TestReadSkipListEmpty(t *testing.T) {
+	sl := NewSkipList[int, int]()
+	var buf bytes.Buffer
+	if _, err := sl.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	got, err := ReadSkipList[int, int](&buf)
+	if err != nil {
+		t.Fatalf("ReadSkipList: %v", err)
+	}
+	if !got.IsEmpty() {
+		t.Fatalf("expected an empty skiplist, got Len() = %d", got.Len())
+	}
+}
+
+func TestReadSkipListBadHeader(t *testing.T) {
+	_, err := ReadSkipList[int, int](bytes.NewReader([]byte("not a skiplist snapshot")))
+	if err == nil {
+		t.Fatalf("expected an error reading a non-snapshot stream")
+	}
+}
+
+// BenchmarkSkipListLoadVsInsert compares rebuilding a skiplist from sorted
+// data (the same tails-array path ReadSkipList uses once it's decoded a
+// snapshot) against inserting the same elements one at a time, to demonstrate
+// that the O(n) rebuild avoids the O(n log n) cost of repeated Insert. n is
+// large enough for that asymptotic gap to dominate encode/decode noise; at
+// small n the two are close enough that benchmark variance can mask the win.
+func BenchmarkSkipListLoadVsInsert(b *testing.B) {
+	const n = 200000
+	keys := make([]int, n)
+	values := make([]int, n)
+	for i := range keys {
+		keys[i] = i
+		values[i] = i
+	}
+
+	b.Run("Insert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sl := NewSkipList[int, int]()
+			for j, k := range keys {
+				sl.Insert(k, values[j])
+			}
+		}
+	})
+
+	b.Run("Load", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewSkipListFromSorted(keys, values)
+		}
+	})
+}
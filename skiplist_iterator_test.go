@@ -0,0 +1,120 @@
+package stl4go
+
+import "testing"
+
+func TestSkipListIteratorEmptyList(t *testing.T) {
+	sl := NewSkipList[int, string]()
+	it := sl.Iterator()
+
+	it.SeekToFirst()
+	if it.Valid() {
+		t.Fatalf("SeekToFirst on empty list should be invalid, got key %v", it.Key())
+	}
+
+	it.SeekToLast()
+	if it.Valid() {
+		t.Fatalf("SeekToLast on empty list should be invalid, got key %v", it.Key())
+	}
+
+	it.Seek(0)
+	if it.Valid() {
+		t.Fatalf("Seek on empty list should be invalid, got key %v", it.Key())
+	}
+
+	it.SeekForPrev(0)
+	if it.Valid() {
+		t.Fatalf("SeekForPrev on empty list should be invalid, got key %v", it.Key())
+	}
+}
+
+func TestSkipListIteratorSeekPastEnd(t *testing.T) {
+	sl := NewSkipList[int, string]()
+	for i := 0; i < 10; i++ {
+		sl.Insert(i*2, "")
+	}
+
+	it := sl.Iterator()
+	it.Seek(100)
+	if it.Valid() {
+		t.Fatalf("Seek(100) should run off the end, got key %v", it.Key())
+	}
+
+	it.SeekToLast()
+	if !it.Valid() || it.Key() != 18 {
+		t.Fatalf("SeekToLast() should land on 18, got %v", it.Key())
+	}
+	it.Next()
+	if it.Valid() {
+		t.Fatalf("Next() past the last element should be invalid, got key %v", it.Key())
+	}
+
+	it.SeekForPrev(-1)
+	if it.Valid() {
+		t.Fatalf("SeekForPrev(-1) should run off the start, got key %v", it.Key())
+	}
+}
+
+func TestSkipListIteratorPrevFromEnd(t *testing.T) {
+	sl := NewSkipList[int, string]()
+	want := []int{1, 3, 5, 7, 9}
+	for _, k := range want {
+		sl.Insert(k, "")
+	}
+
+	it := sl.Iterator()
+	it.SeekToLast()
+
+	var got []int
+	for ; it.Valid(); it.Prev() {
+		got = append(got, it.Key())
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("walked %d keys backwards, want %d: %v", len(got), len(want), got)
+	}
+	for i, k := range got {
+		if k != want[len(want)-1-i] {
+			t.Fatalf("got[%d] = %d, want %d (full: %v)", i, k, want[len(want)-1-i], got)
+		}
+	}
+
+	it.Prev()
+	if it.Valid() {
+		t.Fatalf("Prev() past the first element should be invalid, got key %v", it.Key())
+	}
+}
+
+func TestSkipListRangeAndBounds(t *testing.T) {
+	sl := NewSkipList[int, int]()
+	for i := 0; i < 20; i += 2 {
+		sl.Insert(i, i*10)
+	}
+
+	var got []int
+	sl.Range(5, 15, func(k int, v *int) bool {
+		got = append(got, k)
+		return true
+	})
+	want := []int{6, 8, 10, 12, 14}
+	if len(got) != len(want) {
+		t.Fatalf("Range(5, 15) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Range(5, 15) = %v, want %v", got, want)
+		}
+	}
+
+	if lb := sl.LowerBound(7); !lb.Valid() || lb.Key() != 8 {
+		t.Fatalf("LowerBound(7) = %v, want 8", lb)
+	}
+	if lb := sl.LowerBound(8); !lb.Valid() || lb.Key() != 8 {
+		t.Fatalf("LowerBound(8) = %v, want 8", lb)
+	}
+	if ub := sl.UpperBound(8); !ub.Valid() || ub.Key() != 10 {
+		t.Fatalf("UpperBound(8) = %v, want 10", ub)
+	}
+	if ub := sl.UpperBound(18); ub.Valid() {
+		t.Fatalf("UpperBound(18) should run off the end, got key %v", ub.Key())
+	}
+}
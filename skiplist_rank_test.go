@@ -0,0 +1,184 @@
+package stl4go
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// rankOracle is a plain sorted-slice reference implementation of the rank/select
+// operations SkipList.At/Rank/RemoveAt provide, used to check the span-annotated
+// skiplist against.
+type rankOracle struct {
+	keys []int
+}
+
+func (o *rankOracle) insert(key int) {
+	i := sort.SearchInts(o.keys, key)
+	if i < len(o.keys) && o.keys[i] == key {
+		return
+	}
+	o.keys = append(o.keys, 0)
+	copy(o.keys[i+1:], o.keys[i:])
+	o.keys[i] = key
+}
+
+func (o *rankOracle) removeAt(rank int) int {
+	key := o.keys[rank]
+	o.keys = append(o.keys[:rank], o.keys[rank+1:]...)
+	return key
+}
+
+func (o *rankOracle) rank(key int) int {
+	return sort.SearchInts(o.keys, key)
+}
+
+func TestSkipListRankSelectAgainstOracle(t *testing.T) {
+	// #nosec G404 -- This is a test, not a security condition
+	r := rand.New(rand.NewSource(1))
+	sl := NewSkipList[int, int]()
+	oracle := &rankOracle{}
+
+	const n = 500
+	for i := 0; i < n; i++ {
+		key := r.Intn(n * 2)
+		sl.Insert(key, key*10)
+		oracle.insert(key)
+	}
+
+	if sl.Len() != len(oracle.keys) {
+		t.Fatalf("Len() = %d, want %d", sl.Len(), len(oracle.keys))
+	}
+
+	for rank, key := range oracle.keys {
+		gotKey, gotVal := sl.At(rank)
+		if gotKey != key {
+			t.Fatalf("At(%d) key = %d, want %d", rank, gotKey, key)
+		}
+		if gotVal == nil || *gotVal != key*10 {
+			t.Fatalf("At(%d) value = %v, want %d", rank, gotVal, key*10)
+		}
+	}
+
+	for _, key := range oracle.keys {
+		if got, want := sl.Rank(key), oracle.rank(key); got != want {
+			t.Fatalf("Rank(%d) = %d, want %d", key, got, want)
+		}
+	}
+	// A grid of probe keys, including out-of-range ones and ones never inserted,
+	// should rank the same as in the oracle too.
+	for key := -5; key < n*2+5; key += 7 {
+		if got, want := sl.Rank(key), oracle.rank(key); got != want {
+			t.Fatalf("Rank(%d) = %d, want %d", key, got, want)
+		}
+	}
+
+	if _, v := sl.At(-1); v != nil {
+		t.Fatalf("At(-1) should be out of range, got %v", v)
+	}
+	if _, v := sl.At(sl.Len()); v != nil {
+		t.Fatalf("At(Len()) should be out of range, got %v", v)
+	}
+}
+
+func TestSkipListRemoveAtAgainstOracle(t *testing.T) {
+	// #nosec G404 -- This is a test, not a security condition
+	r := rand.New(rand.NewSource(2))
+	sl := NewSkipList[int, int]()
+	oracle := &rankOracle{}
+
+	const n = 300
+	for i := 0; i < n; i++ {
+		key := r.Intn(n * 2)
+		sl.Insert(key, key)
+		oracle.insert(key)
+	}
+
+	for len(oracle.keys) > 0 {
+		rank := r.Intn(len(oracle.keys))
+		wantKey := oracle.removeAt(rank)
+
+		if !sl.RemoveAt(rank) {
+			t.Fatalf("RemoveAt(%d) = false, want true", rank)
+		}
+		if sl.Has(wantKey) {
+			t.Fatalf("key %d should have been removed", wantKey)
+		}
+		if sl.Len() != len(oracle.keys) {
+			t.Fatalf("Len() = %d, want %d", sl.Len(), len(oracle.keys))
+		}
+
+		for rank, key := range oracle.keys {
+			if gotKey, _ := sl.At(rank); gotKey != key {
+				t.Fatalf("after RemoveAt, At(%d) = %d, want %d", rank, gotKey, key)
+			}
+		}
+	}
+
+	if sl.RemoveAt(0) {
+		t.Fatalf("RemoveAt(0) on an empty list should be false")
+	}
+}
+
+// TestBulkBuiltRankSelectAgainstOracle checks At/Rank against a sorted-slice
+// oracle for lists produced by buildSkipListFromSorted's callers
+// (NewSkipListFromSorted, ReadSkipList, BulkInsert's empty-list fast path),
+// none of which are exercised by TestSkipListRankSelectAgainstOracle since
+// that test only ever calls Insert.
+func TestBulkBuiltRankSelectAgainstOracle(t *testing.T) {
+	const n = 500
+	keys := make([]int, n)
+	values := make([]int, n)
+	oracle := &rankOracle{}
+	for i := range keys {
+		keys[i] = i * 2
+		values[i] = i * 20
+		oracle.insert(keys[i])
+	}
+
+	checkAgainstOracle := func(t *testing.T, sl *SkipList[int, int]) {
+		t.Helper()
+		if sl.Len() != len(oracle.keys) {
+			t.Fatalf("Len() = %d, want %d", sl.Len(), len(oracle.keys))
+		}
+		for rank, key := range oracle.keys {
+			gotKey, gotVal := sl.At(rank)
+			if gotKey != key {
+				t.Fatalf("At(%d) key = %d, want %d", rank, gotKey, key)
+			}
+			if gotVal == nil || *gotVal != key*10 {
+				t.Fatalf("At(%d) value = %v, want %d", rank, gotVal, key*10)
+			}
+			if got, want := sl.Rank(key), oracle.rank(key); got != want {
+				t.Fatalf("Rank(%d) = %d, want %d", key, got, want)
+			}
+		}
+	}
+
+	t.Run("NewSkipListFromSorted", func(t *testing.T) {
+		checkAgainstOracle(t, NewSkipListFromSorted(keys, values))
+	})
+
+	t.Run("ReadSkipList", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := NewSkipListFromSorted(keys, values).WriteTo(&buf); err != nil {
+			t.Fatalf("WriteTo: %v", err)
+		}
+		got, err := ReadSkipList[int, int](&buf)
+		if err != nil {
+			t.Fatalf("ReadSkipList: %v", err)
+		}
+		checkAgainstOracle(t, got)
+	})
+
+	t.Run("BulkInsert", func(t *testing.T) {
+		pairs := make([]Pair[int, int], n)
+		for i, key := range keys {
+			pairs[i] = Pair[int, int]{First: key, Second: values[i]}
+		}
+		sl := NewSkipList[int, int]()
+		sl.BulkInsert(pairs)
+		checkAgainstOracle(t, sl)
+	})
+}